@@ -0,0 +1,194 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// authenticator holds the Client's long-lived API secret. It signs nothing
+// itself: requests are signed by a TokenProvider (see WithTokenProvider),
+// by default a StaticSecretProvider over the same secret, and the secret is
+// also used to verify webhook signatures (see VerifyWebhook).
+type authenticator struct {
+	secret string
+}
+
+// TokenProvider supplies short-lived credentials used to sign requests to
+// the Stream API, in place of a long-lived API secret. scope identifies
+// what the token should be valid for, eg. a feed group or "*" for
+// unrestricted server-side access.
+type TokenProvider interface {
+	// Token returns a signed token valid for scope, along with when it
+	// expires. A zero expiresAt means the token does not expire.
+	Token(ctx context.Context, scope string) (token string, expiresAt time.Time, err error)
+}
+
+// WithTokenProvider configures the Client to sign requests using provider
+// instead of the long-lived API secret passed to NewClient, enabling secret
+// rotation (eg. via a vault or a sidecar minting scoped Stream JWTs) without
+// a process restart.
+func WithTokenProvider(provider TokenProvider) ClientOption {
+	return func(c *Client) { c.tokenProvider = provider }
+}
+
+// StaticSecretProvider is a TokenProvider that signs every request with a
+// single long-lived API secret, matching the Client's default behavior.
+type StaticSecretProvider struct {
+	secret string
+}
+
+// NewStaticSecretProvider returns a StaticSecretProvider that signs tokens
+// with secret.
+func NewStaticSecretProvider(secret string) *StaticSecretProvider {
+	return &StaticSecretProvider{secret: secret}
+}
+
+// Token implements TokenProvider. The returned token never expires.
+func (p *StaticSecretProvider) Token(_ context.Context, scope string) (string, time.Time, error) {
+	token, err := signJWT(p.secret, scope)
+	return token, time.Time{}, err
+}
+
+// rotationRetryInterval is how long CachingProvider waits before retrying a
+// background rotation that failed, eg. because the wrapped TokenProvider's
+// backing service was briefly unreachable.
+const rotationRetryInterval = time.Second
+
+// CachingProvider wraps another TokenProvider, fetching its token for a
+// given scope synchronously once (again after a failed fetch), then
+// proactively rotating it in the background on a timer shortly before it
+// expires, so callers don't block on the network once warmed up.
+type CachingProvider struct {
+	provider      TokenProvider
+	refreshBefore time.Duration
+
+	mu       sync.Mutex
+	tokens   map[string]*cachedToken
+	rotating map[string]bool
+}
+
+type cachedToken struct {
+	once      sync.Once
+	token     string
+	expiresAt time.Time
+	err       error
+}
+
+// NewCachingProvider returns a CachingProvider wrapping provider. Tokens are
+// proactively rotated once they're within refreshBefore of expiring.
+func NewCachingProvider(provider TokenProvider, refreshBefore time.Duration) *CachingProvider {
+	return &CachingProvider{
+		provider:      provider,
+		refreshBefore: refreshBefore,
+		tokens:        map[string]*cachedToken{},
+		rotating:      map[string]bool{},
+	}
+}
+
+// Token implements TokenProvider.
+func (p *CachingProvider) Token(ctx context.Context, scope string) (string, time.Time, error) {
+	p.mu.Lock()
+	ct, ok := p.tokens[scope]
+	if !ok {
+		ct = &cachedToken{}
+		p.tokens[scope] = ct
+	}
+	p.mu.Unlock()
+
+	ct.once.Do(func() {
+		ct.token, ct.expiresAt, ct.err = p.provider.Token(ctx, scope)
+		if ct.err == nil {
+			p.scheduleRotation(scope, ct.expiresAt)
+		}
+	})
+	if ct.err != nil {
+		// Don't let a transient failure poison this scope forever: drop the
+		// cached entry so the next call fetches a fresh token instead of
+		// replaying the same error indefinitely.
+		p.mu.Lock()
+		if p.tokens[scope] == ct {
+			delete(p.tokens, scope)
+		}
+		p.mu.Unlock()
+		return "", time.Time{}, ct.err
+	}
+
+	return ct.token, ct.expiresAt, nil
+}
+
+// scheduleRotation arms a timer that refreshes scope's token in the
+// background once it's within refreshBefore of expiresAt, without waiting
+// for a caller to invoke Token again. A zero expiresAt means the token
+// doesn't expire, so nothing is scheduled.
+func (p *CachingProvider) scheduleRotation(scope string, expiresAt time.Time) {
+	if expiresAt.IsZero() {
+		return
+	}
+
+	delay := time.Until(expiresAt.Add(-p.refreshBefore))
+	if delay < 0 {
+		delay = 0
+	}
+
+	time.AfterFunc(delay, func() { p.rotate(scope) })
+}
+
+// rotate fetches a fresh token for scope in the background, swapping it in
+// once ready and arming the next rotation. In-flight callers keep receiving
+// the still-valid cached token in the meantime, and concurrent calls to
+// rotate for the same scope are collapsed into one fetch. A failed fetch is
+// retried after rotationRetryInterval rather than abandoning rotation for
+// the scope.
+func (p *CachingProvider) rotate(scope string) {
+	p.mu.Lock()
+	if p.rotating[scope] {
+		p.mu.Unlock()
+		return
+	}
+	p.rotating[scope] = true
+	p.mu.Unlock()
+
+	go func() {
+		defer func() {
+			p.mu.Lock()
+			delete(p.rotating, scope)
+			p.mu.Unlock()
+		}()
+
+		fresh := &cachedToken{}
+		fresh.token, fresh.expiresAt, fresh.err = p.provider.Token(context.Background(), scope)
+		if fresh.err != nil {
+			time.AfterFunc(rotationRetryInterval, func() { p.rotate(scope) })
+			return
+		}
+		// Mark the fetch as already done so the next Token call finds a
+		// warm entry instead of triggering another synchronous fetch via
+		// ct.once.Do.
+		fresh.once.Do(func() {})
+
+		p.mu.Lock()
+		p.tokens[scope] = fresh
+		p.mu.Unlock()
+
+		p.scheduleRotation(scope, fresh.expiresAt)
+	}()
+}
+
+// signJWT signs a scoped server-side token with secret, the same signing
+// scheme the Client historically used for every request.
+func signJWT(secret, scope string) (string, error) {
+	claims := jwt.MapClaims{
+		"resource": scope,
+		"action":   "*",
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("stream: signing token: %w", err)
+	}
+	return signed, nil
+}
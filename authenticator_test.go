@@ -0,0 +1,99 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+)
+
+type fakeProvider struct {
+	calls int32
+	err   error
+	ttl   time.Duration
+
+	// rotatedTTL, if non-zero, is used for every call after the first
+	// instead of ttl, so tests can arrange for exactly one background
+	// rotation to happen instead of an unbounded rotation loop.
+	rotatedTTL time.Duration
+}
+
+func (p *fakeProvider) Token(_ context.Context, _ string) (string, time.Time, error) {
+	n := atomic.AddInt32(&p.calls, 1)
+	if p.err != nil {
+		return "", time.Time{}, p.err
+	}
+
+	ttl := p.ttl
+	if n > 1 && p.rotatedTTL != 0 {
+		ttl = p.rotatedTTL
+	}
+	return fmt.Sprintf("token-%d", n), time.Now().Add(ttl), nil
+}
+
+func TestCachingProvider_cachesUntilNearExpiry(t *testing.T) {
+	fp := &fakeProvider{ttl: time.Hour}
+	cp := stream.NewCachingProvider(fp, time.Minute)
+
+	token1, _, err := cp.Token(context.Background(), "scope")
+	require.NoError(t, err)
+
+	token2, _, err := cp.Token(context.Background(), "scope")
+	require.NoError(t, err)
+
+	assert.Equal(t, token1, token2)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&fp.calls))
+}
+
+func TestCachingProvider_rotatesInBackgroundWithoutBlocking(t *testing.T) {
+	// refreshBefore is close enough to the token's ttl that a rotation timer
+	// fires shortly after the very first fetch, with no caller needed to
+	// trigger it. The rotated token gets a long ttl so the test isn't racing
+	// against a second, third, ... background rotation.
+	fp := &fakeProvider{ttl: 20 * time.Millisecond, rotatedTTL: time.Hour}
+	cp := stream.NewCachingProvider(fp, 15*time.Millisecond)
+
+	token1, _, err := cp.Token(context.Background(), "scope")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&fp.calls) >= 2
+	}, time.Second, 5*time.Millisecond, "expected a background rotation to fetch a second token")
+
+	// Once the rotation has landed, subsequent calls should return the new
+	// token immediately, not trigger yet another synchronous fetch.
+	require.Eventually(t, func() bool {
+		token2, _, err := cp.Token(context.Background(), "scope")
+		return err == nil && token2 != token1
+	}, time.Second, 5*time.Millisecond, "expected the rotated token to be served")
+
+	callsAfterRotation := atomic.LoadInt32(&fp.calls)
+
+	for i := 0; i < 5; i++ {
+		_, _, err := cp.Token(context.Background(), "scope")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, callsAfterRotation, atomic.LoadInt32(&fp.calls),
+		"serving the already-rotated token should not trigger another fetch")
+}
+
+func TestCachingProvider_retriesAfterTransientFailure(t *testing.T) {
+	fp := &fakeProvider{ttl: time.Hour, err: errors.New("boom")}
+	cp := stream.NewCachingProvider(fp, time.Minute)
+
+	_, _, err := cp.Token(context.Background(), "scope")
+	require.Error(t, err)
+
+	fp.err = nil
+	token, _, err := cp.Token(context.Background(), "scope")
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+}
@@ -0,0 +1,140 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+const (
+	defaultVersion        = "v1.0"
+	defaultBaseURLFormat  = "https://api.stream-io-api.com/api/%s/"
+	regionalBaseURLFormat = "https://%s-api.stream-io-api.com/api/%s/"
+)
+
+// apiURL holds the region and version used to build request URLs.
+type apiURL struct {
+	region  string
+	version string
+}
+
+// Client is a client used to interact with the Stream API.
+type Client struct {
+	key           string
+	authenticator authenticator
+	url           *apiURL
+	http          *http.Client
+
+	instrumentation Instrumentation
+	retryPolicy     *RetryPolicy
+	rateLimiter     RateLimiter
+	tokenProvider   TokenProvider
+}
+
+// ClientOption is a function used to configure a new Client.
+type ClientOption func(*Client)
+
+// WithAPIRegion sets the region used when building request URLs.
+func WithAPIRegion(region string) ClientOption {
+	return func(c *Client) { c.url.region = region }
+}
+
+// WithAPIVersion sets the API version used when building request URLs.
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) { c.url.version = version }
+}
+
+// WithHTTPClient sets the http.Client used to perform requests, allowing
+// callers to customize transport-level behavior such as timeouts, proxies,
+// or a custom http.RoundTripper.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) { c.http = client }
+}
+
+// NewClient creates a new Client for the given API key and secret.
+func NewClient(key, secret string, opts ...ClientOption) (*Client, error) {
+	if key == "" || secret == "" {
+		return nil, errors.New("key and secret are required")
+	}
+
+	c := &Client{
+		key:           key,
+		authenticator: authenticator{secret: secret},
+		url:           &apiURL{},
+		http:          http.DefaultClient,
+		tokenProvider: NewStaticSecretProvider(secret),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// NewClientFromEnv creates a new Client using STREAM_API_KEY, STREAM_API_SECRET,
+// STREAM_API_REGION, and STREAM_API_VERSION environment variables.
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	key := os.Getenv("STREAM_API_KEY")
+	secret := os.Getenv("STREAM_API_SECRET")
+
+	if region := os.Getenv("STREAM_API_REGION"); region != "" {
+		opts = append(opts, WithAPIRegion(region))
+	}
+	if version := os.Getenv("STREAM_API_VERSION"); version != "" {
+		opts = append(opts, WithAPIVersion(version))
+	}
+
+	return NewClient(key, secret, opts...)
+}
+
+func (c *Client) baseURL() string {
+	if u := os.Getenv("STREAM_URL"); u != "" {
+		return u
+	}
+
+	version := c.url.version
+	if version == "" {
+		version = defaultVersion
+	}
+
+	if c.url.region == "" {
+		return fmt.Sprintf(defaultBaseURLFormat, version)
+	}
+	return fmt.Sprintf(regionalBaseURLFormat, c.url.region, version)
+}
+
+// makeEndpoint builds the full URL for an API call, formatting path according
+// to format and args and attaching the client's api_key query parameter.
+func (c *Client) makeEndpoint(format string, args ...interface{}) *url.URL {
+	path := fmt.Sprintf(format, args...)
+	u, err := url.Parse(c.baseURL() + path)
+	if err != nil {
+		// baseURL and path are always well-formed, this should never happen.
+		panic(err)
+	}
+
+	q := u.Query()
+	q.Set("api_key", c.key)
+	u.RawQuery = q.Encode()
+
+	return u
+}
+
+// makeStreamError decodes an APIError from an API response body.
+func (c *Client) makeStreamError(body io.Reader) error {
+	if body == nil {
+		return fmt.Errorf("invalid body")
+	}
+
+	var apiErr APIError
+	if err := json.NewDecoder(body).Decode(&apiErr); err != nil {
+		return err
+	}
+
+	return classify(apiErr)
+}
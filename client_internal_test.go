@@ -3,6 +3,7 @@ package stream
 import (
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
@@ -172,3 +173,58 @@ func Test_makeStreamError(t *testing.T) {
 		}
 	}
 }
+
+func Test_makeStreamError_classification(t *testing.T) {
+	body := func(statusCode int) io.Reader {
+		return strings.NewReader(fmt.Sprintf(`{"detail":"test", "status_code": %d, "duration": "30s"}`, statusCode))
+	}
+
+	testCases := []struct {
+		name       string
+		statusCode int
+		checkAs    func(t *testing.T, err error)
+		retryable  bool
+	}{
+		{name: "rate limited", statusCode: http.StatusTooManyRequests, retryable: true, checkAs: func(t *testing.T, err error) {
+			var target *ErrRateLimited
+			require.ErrorAs(t, err, &target)
+		}},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, retryable: false, checkAs: func(t *testing.T, err error) {
+			var target *ErrAuthentication
+			require.ErrorAs(t, err, &target)
+		}},
+		{name: "forbidden", statusCode: http.StatusForbidden, retryable: false, checkAs: func(t *testing.T, err error) {
+			var target *ErrAuthentication
+			require.ErrorAs(t, err, &target)
+		}},
+		{name: "not found", statusCode: http.StatusNotFound, retryable: false, checkAs: func(t *testing.T, err error) {
+			var target *ErrNotFound
+			require.ErrorAs(t, err, &target)
+		}},
+		{name: "bad request", statusCode: http.StatusBadRequest, retryable: false, checkAs: func(t *testing.T, err error) {
+			var target *ErrValidation
+			require.ErrorAs(t, err, &target)
+		}},
+		{name: "server error", statusCode: http.StatusInternalServerError, retryable: true, checkAs: func(t *testing.T, err error) {
+			var target *ErrServerUnavailable
+			require.ErrorAs(t, err, &target)
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&Client{}).makeStreamError(body(tc.statusCode))
+			tc.checkAs(t, err)
+
+			var apiErr APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tc.retryable, apiErr.IsRetryable())
+		})
+	}
+
+	t.Run("rate limited exposes RetryAfter", func(t *testing.T) {
+		var rateLimited *ErrRateLimited
+		err := (&Client{}).makeStreamError(body(http.StatusTooManyRequests))
+		require.ErrorAs(t, err, &rateLimited)
+		assert.Equal(t, 30*time.Second, rateLimited.RetryAfter)
+	})
+}
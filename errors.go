@@ -0,0 +1,105 @@
+package stream
+
+import (
+	"net/http"
+	"time"
+)
+
+// APIError is the error envelope returned by the Stream API on failed
+// requests.
+type APIError struct {
+	Code            int                      `json:"code"`
+	Detail          string                   `json:"detail"`
+	Duration        Duration                 `json:"duration"`
+	Exception       string                   `json:"exception"`
+	StatusCode      int                      `json:"status_code"`
+	ExceptionFields map[string][]interface{} `json:"exception_fields"`
+}
+
+// Error implements the error interface.
+func (e APIError) Error() string {
+	return e.Detail
+}
+
+// IsTransient reports whether e reflects a transient failure on Stream's
+// side, as opposed to a problem with the request itself, and is therefore
+// likely to succeed if retried unchanged after a short delay.
+func (e APIError) IsTransient() bool {
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
+// IsRetryable reports whether the request that produced e is worth
+// retrying, which includes transient server errors as well as requests
+// rejected by rate limiting.
+func (e APIError) IsRetryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.IsTransient()
+}
+
+// classify wraps apiErr in a more specific error type based on its
+// StatusCode, so callers can use errors.As instead of switching on numeric
+// status or exception codes. The wrapper types define no Is method and
+// there are no sentinel values, so errors.Is does not match them.
+// StatusCodes that don't map to one of the known classifications are
+// returned unwrapped, preserving the historical plain-APIError behavior.
+func classify(apiErr APIError) error {
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests:
+		return &ErrRateLimited{APIError: apiErr, RetryAfter: apiErr.Duration.Duration}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &ErrAuthentication{APIError: apiErr}
+	case http.StatusNotFound:
+		return &ErrNotFound{APIError: apiErr}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &ErrValidation{APIError: apiErr, Fields: apiErr.ExceptionFields}
+	default:
+		if apiErr.IsTransient() {
+			return &ErrServerUnavailable{APIError: apiErr}
+		}
+		return apiErr
+	}
+}
+
+// ErrRateLimited is returned when a request was rejected by Stream's rate
+// limiter.
+type ErrRateLimited struct {
+	APIError
+	// RetryAfter is how long to wait before retrying, parsed from the
+	// APIError's Duration field.
+	RetryAfter time.Duration
+}
+
+func (e *ErrRateLimited) Unwrap() error { return e.APIError }
+
+// ErrAuthentication is returned when a request was rejected because of
+// invalid or missing credentials.
+type ErrAuthentication struct {
+	APIError
+}
+
+func (e *ErrAuthentication) Unwrap() error { return e.APIError }
+
+// ErrNotFound is returned when a request referenced a resource that doesn't
+// exist.
+type ErrNotFound struct {
+	APIError
+}
+
+func (e *ErrNotFound) Unwrap() error { return e.APIError }
+
+// ErrValidation is returned when a request was rejected as invalid. Fields
+// mirrors the APIError's ExceptionFields, naming the specific fields that
+// failed validation and why.
+type ErrValidation struct {
+	APIError
+	Fields map[string][]interface{}
+}
+
+func (e *ErrValidation) Unwrap() error { return e.APIError }
+
+// ErrServerUnavailable is returned when a request failed because of a
+// transient problem on Stream's side.
+type ErrServerUnavailable struct {
+	APIError
+}
+
+func (e *ErrServerUnavailable) Unwrap() error { return e.APIError }
@@ -0,0 +1,122 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit describes the rate-limit state reported by the Stream API for a
+// single request, as parsed from the X-Ratelimit-* response headers.
+type RateLimit struct {
+	// Remaining is the number of requests left in the current window, or -1
+	// if the response did not include rate-limit headers.
+	Remaining int
+	// Reset is when the current rate-limit window resets.
+	Reset time.Time
+}
+
+// RequestInfo describes a single Stream API request, passed to an
+// Instrumentation's Before and After hooks.
+type RequestInfo struct {
+	Method   string
+	Endpoint string
+	APIKey   string
+	Region   string
+	Version  string
+
+	StatusCode int
+	RateLimit  RateLimit
+	Duration   time.Duration
+	Err        error
+}
+
+// Instrumentation lets callers observe the requests a Client makes to the
+// Stream API, eg. to export traces and metrics. See the stream/otel
+// subpackage for an OpenTelemetry-backed implementation.
+type Instrumentation interface {
+	// Before is called immediately before a request is sent. The returned
+	// context is used for the remainder of the request, including the
+	// matching call to After.
+	Before(ctx context.Context, info RequestInfo) context.Context
+	// After is called once a request has completed, successfully or not.
+	After(ctx context.Context, info RequestInfo)
+}
+
+// WithInstrumentation sets the Instrumentation used to observe API calls
+// made by the Client.
+func WithInstrumentation(i Instrumentation) ClientOption {
+	return func(c *Client) { c.instrumentation = i }
+}
+
+// Instrumentation returns the Client's currently configured Instrumentation,
+// or nil if none has been set. It's mainly useful to subpackages, such as
+// stream/otel, that need to compose onto an already-configured
+// Instrumentation.
+func (c *Client) Instrumentation() Instrumentation {
+	return c.instrumentation
+}
+
+func parseRateLimit(h http.Header) RateLimit {
+	rl := RateLimit{Remaining: -1}
+
+	if v := h.Get("X-Ratelimit-Remaining"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			rl.Remaining = n
+		}
+	}
+
+	if v := h.Get("X-Ratelimit-Reset"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			rl.Reset = time.Unix(n, 0)
+		}
+	}
+
+	return rl
+}
+
+// do performs an HTTP request against the Stream API, instrumenting it via
+// the Client's configured Instrumentation, if any, and translating responses
+// with a 4xx or 5xx status into an error via makeStreamError.
+func (c *Client) do(ctx context.Context, req *http.Request, endpoint string) (*http.Response, error) {
+	info := RequestInfo{
+		Method:   req.Method,
+		Endpoint: endpoint,
+		APIKey:   c.key,
+		Region:   c.url.region,
+		Version:  c.url.version,
+	}
+
+	if c.instrumentation != nil {
+		ctx = c.instrumentation.Before(ctx, info)
+		req = req.WithContext(ctx)
+	}
+
+	start := time.Now()
+	resp, err := c.http.Do(req)
+	info.Duration = time.Since(start)
+
+	if err != nil {
+		info.Err = err
+		c.afterRequest(ctx, info)
+		return nil, err
+	}
+
+	info.StatusCode = resp.StatusCode
+	info.RateLimit = parseRateLimit(resp.Header)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		info.Err = c.makeStreamError(resp.Body)
+		resp.Body.Close()
+	}
+
+	c.afterRequest(ctx, info)
+	return resp, info.Err
+}
+
+func (c *Client) afterRequest(ctx context.Context, info RequestInfo) {
+	if c.instrumentation != nil {
+		c.instrumentation.After(ctx, info)
+	}
+}
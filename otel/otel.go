@@ -0,0 +1,148 @@
+// Package otel provides an OpenTelemetry-backed stream.Instrumentation,
+// exporting a span and a set of metrics for every API call made by a
+// stream.Client. It lives in its own module-level subpackage so that the
+// core stream package doesn't pull in the OpenTelemetry SDK unless a caller
+// opts in.
+package otel
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+)
+
+const instrumentationName = "github.com/flockfw64/stream-go2/v7/otel"
+
+// Instrumentation is a stream.Instrumentation that records a span and a set
+// of metrics for every Stream API call. Construct one via WithTracing and/or
+// WithMetrics rather than directly.
+type Instrumentation struct {
+	tracer trace.Tracer
+	meter  metric.Meter
+
+	requestCount       metric.Int64Counter
+	requestLatency     metric.Float64Histogram
+	rateLimitRemaining metric.Int64Gauge
+	rateLimitReset     metric.Float64Gauge
+}
+
+// WithTracing returns a stream.ClientOption that records a span, named after
+// the requested endpoint, for every API call the Client makes. It composes
+// with WithMetrics: calling both configures a single Instrumentation with
+// both a tracer and a meter.
+func WithTracing(tp trace.TracerProvider) stream.ClientOption {
+	return func(c *stream.Client) {
+		i := instrumentationFor(c)
+		i.tracer = tp.Tracer(instrumentationName)
+		stream.WithInstrumentation(i)(c)
+	}
+}
+
+// WithMetrics returns a stream.ClientOption that records per-endpoint
+// request counts, latency, and rate-limit gauges for every API call the
+// Client makes. It composes with WithTracing; see its docs.
+func WithMetrics(mp metric.MeterProvider) stream.ClientOption {
+	return func(c *stream.Client) {
+		i := instrumentationFor(c)
+		i.meter = mp.Meter(instrumentationName)
+
+		// Errors from instrument creation are only possible for invalid
+		// names/options, which are fixed at compile time here, so they're
+		// safe to ignore.
+		i.requestCount, _ = i.meter.Int64Counter(
+			"stream.request.count",
+			metric.WithDescription("Number of Stream API requests made, by endpoint and status code."),
+		)
+		i.requestLatency, _ = i.meter.Float64Histogram(
+			"stream.request.latency",
+			metric.WithUnit("s"),
+			metric.WithDescription("Stream API request latency, by endpoint and status code."),
+		)
+		i.rateLimitRemaining, _ = i.meter.Int64Gauge(
+			"stream.ratelimit.remaining",
+			metric.WithDescription("Requests remaining in the current Stream API rate-limit window."),
+		)
+		i.rateLimitReset, _ = i.meter.Float64Gauge(
+			"stream.ratelimit.reset",
+			metric.WithUnit("s"),
+			metric.WithDescription("Seconds until the current Stream API rate-limit window resets."),
+		)
+
+		stream.WithInstrumentation(i)(c)
+	}
+}
+
+// instrumentationFor returns the Client's current *Instrumentation, creating
+// one if it doesn't already have one configured.
+func instrumentationFor(c *stream.Client) *Instrumentation {
+	if i, ok := c.Instrumentation().(*Instrumentation); ok && i != nil {
+		return i
+	}
+	return &Instrumentation{}
+}
+
+// Before implements stream.Instrumentation.
+func (i *Instrumentation) Before(ctx context.Context, info stream.RequestInfo) context.Context {
+	if i.tracer == nil {
+		return ctx
+	}
+
+	ctx, span := i.tracer.Start(ctx, info.Endpoint, trace.WithAttributes(
+		attribute.String("stream.api_key", info.APIKey),
+		attribute.String("stream.region", info.Region),
+		attribute.String("stream.version", info.Version),
+		attribute.String("http.method", info.Method),
+	))
+	_ = span // span is retrieved back from ctx in After via trace.SpanFromContext
+
+	return ctx
+}
+
+// After implements stream.Instrumentation.
+func (i *Instrumentation) After(ctx context.Context, info stream.RequestInfo) {
+	if i.tracer != nil {
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(
+			attribute.Int("http.status_code", info.StatusCode),
+			attribute.Int("stream.ratelimit.remaining", info.RateLimit.Remaining),
+		)
+
+		if info.Err != nil {
+			span.RecordError(info.Err)
+			span.SetStatus(codes.Error, info.Err.Error())
+
+			var apiErr stream.APIError
+			if errors.As(info.Err, &apiErr) {
+				span.SetAttributes(
+					attribute.Int("stream.error.code", apiErr.Code),
+					attribute.String("stream.error.exception", apiErr.Exception),
+				)
+			}
+		}
+
+		span.End()
+	}
+
+	if i.meter != nil {
+		attrs := metric.WithAttributes(
+			attribute.String("stream.endpoint", info.Endpoint),
+			attribute.Int("http.status_code", info.StatusCode),
+		)
+
+		i.requestCount.Add(ctx, 1, attrs)
+		i.requestLatency.Record(ctx, info.Duration.Seconds(), attrs)
+		if info.RateLimit.Remaining >= 0 {
+			i.rateLimitRemaining.Record(ctx, int64(info.RateLimit.Remaining), attrs)
+		}
+		if !info.RateLimit.Reset.IsZero() {
+			i.rateLimitReset.Record(ctx, time.Until(info.RateLimit.Reset).Seconds(), attrs)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package otel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+	streamotel "github.com/flockfw64/stream-go2/v7/otel"
+)
+
+func TestInstrumentation_RecordsSpanAndMetrics(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	client, err := stream.NewClient("key", "secret",
+		streamotel.WithTracing(tp),
+		streamotel.WithMetrics(mp),
+	)
+	require.NoError(t, err)
+
+	info := stream.RequestInfo{
+		Method:   "GET",
+		Endpoint: "feed/flat/user/",
+		APIKey:   "key",
+		Region:   "us-east",
+		Version:  "v1.0",
+	}
+
+	ctx := client.Instrumentation().Before(context.Background(), info)
+
+	info.StatusCode = 200
+	info.Duration = 50 * time.Millisecond
+	info.RateLimit = stream.RateLimit{Remaining: 99, Reset: time.Now().Add(time.Minute)}
+	client.Instrumentation().After(ctx, info)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "feed/flat/user/", spans[0].Name)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.NotEmpty(t, rm.ScopeMetrics)
+
+	var names []string
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		names = append(names, m.Name)
+	}
+	assert.Contains(t, names, "stream.request.count")
+	assert.Contains(t, names, "stream.request.latency")
+	assert.Contains(t, names, "stream.ratelimit.remaining")
+	assert.Contains(t, names, "stream.ratelimit.reset")
+}
+
+func TestInstrumentation_RecordsErrorOnSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client, err := stream.NewClient("key", "secret", streamotel.WithTracing(tp))
+	require.NoError(t, err)
+
+	info := stream.RequestInfo{Endpoint: "feed/flat/user/"}
+	ctx := client.Instrumentation().Before(context.Background(), info)
+
+	info.StatusCode = 429
+	info.Err = stream.APIError{Code: 9, Exception: "rate-limit-reached", StatusCode: 429, Detail: "too many requests"}
+	client.Instrumentation().After(ctx, info)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.NotEmpty(t, spans[0].Status.Description)
+}
@@ -0,0 +1,181 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Backoff computes how long to wait before a given retry attempt (1-indexed:
+// the first retry is attempt 1).
+type Backoff interface {
+	Backoff(attempt int) time.Duration
+}
+
+// ExponentialBackoff is a Backoff that doubles Base on every attempt, up to
+// Max, and adds up to Jitter*delay of random jitter to avoid thundering-herd
+// retries.
+type ExponentialBackoff struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter float64
+}
+
+// Backoff implements Backoff.
+func (b ExponentialBackoff) Backoff(attempt int) time.Duration {
+	delay := b.Base << uint(attempt-1)
+	if b.Max > 0 && delay > b.Max {
+		delay = b.Max
+	}
+
+	if b.Jitter > 0 {
+		delay += time.Duration(rand.Float64() * b.Jitter * float64(delay))
+	}
+
+	return delay
+}
+
+// defaultBackoff is used by a RetryPolicy that doesn't set Backoff.
+var defaultBackoff = ExponentialBackoff{
+	Base:   200 * time.Millisecond,
+	Max:    10 * time.Second,
+	Jitter: 0.2,
+}
+
+// RetryPolicy configures how a Client retries failed requests.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. A value <= 1 disables retries.
+	MaxAttempts int
+	// Backoff computes the delay between attempts. Defaults to an
+	// ExponentialBackoff with jitter.
+	Backoff Backoff
+	// IsRetryable decides whether a failed request should be retried based
+	// on the APIError it failed with. Defaults to retrying rate-limit (429)
+	// and server (5xx) status codes.
+	IsRetryable func(APIError) bool
+	// OnRetry, if set, is called before each retry with the attempt number
+	// (1-indexed), the error that triggered it, and how long the Client
+	// will wait before retrying.
+	OnRetry func(attempt int, err error, wait time.Duration)
+}
+
+func (p RetryPolicy) backoff() Backoff {
+	if p.Backoff != nil {
+		return p.Backoff
+	}
+	return defaultBackoff
+}
+
+func (p RetryPolicy) isRetryable(apiErr APIError) bool {
+	if p.IsRetryable != nil {
+		return p.IsRetryable(apiErr)
+	}
+	return apiErr.IsRetryable()
+}
+
+// WithRetryPolicy configures the Client to retry failed requests according
+// to policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) { c.retryPolicy = &policy }
+}
+
+// RateLimiter throttles outgoing requests client-side, eg. via a token
+// bucket. Wait blocks until a request is allowed to proceed, or ctx is
+// canceled.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter configures the Client to pass every outgoing request
+// through rl before sending it.
+func WithRateLimiter(rl RateLimiter) ClientOption {
+	return func(c *Client) { c.rateLimiter = rl }
+}
+
+// call sends a request to endpoint, scoped to scope for signing purposes
+// (see TokenProvider), applying the Client's configured RateLimiter and
+// RetryPolicy, and retrying on transport errors or retryable APIErrors
+// until the policy is exhausted or ctx is done.
+func (c *Client) call(ctx context.Context, method string, endpoint *url.URL, scope string, body []byte) (*http.Response, error) {
+	maxAttempts := 1
+	var policy RetryPolicy
+	if c.retryPolicy != nil {
+		policy = *c.retryPolicy
+		if policy.MaxAttempts > 1 {
+			maxAttempts = policy.MaxAttempts
+		}
+	}
+
+	var resp *http.Response
+	var err error
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if c.rateLimiter != nil {
+			if werr := c.rateLimiter.Wait(ctx); werr != nil {
+				return nil, werr
+			}
+		}
+
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+
+		req, rerr := http.NewRequestWithContext(ctx, method, endpoint.String(), reqBody)
+		if rerr != nil {
+			return nil, rerr
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		token, _, terr := c.tokenProvider.Token(ctx, scope)
+		if terr != nil {
+			return nil, fmt.Errorf("stream: signing request: %w", terr)
+		}
+		req.Header.Set("Authorization", token)
+		req.Header.Set("Stream-Auth-Type", "jwt")
+
+		resp, err = c.do(ctx, req, endpoint.Path)
+		if !shouldRetry(policy, attempt, maxAttempts, err) {
+			return resp, err
+		}
+
+		wait := policy.backoff().Backoff(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return resp, err
+}
+
+func shouldRetry(policy RetryPolicy, attempt, maxAttempts int, err error) bool {
+	if err == nil || attempt >= maxAttempts {
+		return false
+	}
+
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		// Transport-level errors (timeouts, connection resets, ...) are
+		// always retryable as long as attempts remain.
+		return true
+	}
+
+	return policy.isRetryable(apiErr)
+}
@@ -0,0 +1,124 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_call_retriesOnRetryableError(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"detail":"unavailable","status_code":503}`)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("key", "secret", WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     ExponentialBackoff{Base: time.Millisecond},
+	}))
+	require.NoError(t, err)
+
+	u, _ := url.Parse(srv.URL)
+	resp, err := c.call(context.Background(), http.MethodGet, u, "*", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestClient_call_stopsRetryingOnNonRetryableError(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"detail":"bad","status_code":400}`)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("key", "secret", WithRetryPolicy(RetryPolicy{MaxAttempts: 5}))
+	require.NoError(t, err)
+
+	u, _ := url.Parse(srv.URL)
+	_, err = c.call(context.Background(), http.MethodGet, u, "*", nil)
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestClient_call_respectsContextDeadlineAcrossRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"detail":"unavailable","status_code":503}`)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("key", "secret", WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 10,
+		Backoff:     ExponentialBackoff{Base: 50 * time.Millisecond},
+	}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Millisecond)
+	defer cancel()
+
+	u, _ := url.Parse(srv.URL)
+	_, err = c.call(ctx, http.MethodGet, u, "*", nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+type staticRateLimiter struct {
+	calls int
+}
+
+func (r *staticRateLimiter) Wait(context.Context) error {
+	r.calls++
+	return nil
+}
+
+func TestClient_call_appliesRateLimiter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	rl := &staticRateLimiter{}
+	c, err := NewClient("key", "secret", WithRateLimiter(rl))
+	require.NoError(t, err)
+
+	u, _ := url.Parse(srv.URL)
+	_, err = c.call(context.Background(), http.MethodGet, u, "*", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rl.calls)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second}
+	assert.Equal(t, 100*time.Millisecond, b.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, b.Backoff(2))
+	assert.Equal(t, 400*time.Millisecond, b.Backoff(3))
+	assert.Equal(t, time.Second, b.Backoff(10))
+}
+
+func TestRetryPolicy_isRetryable(t *testing.T) {
+	defaultPolicy := RetryPolicy{}
+	assert.True(t, defaultPolicy.isRetryable(APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, defaultPolicy.isRetryable(APIError{StatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, defaultPolicy.isRetryable(APIError{StatusCode: http.StatusBadRequest}))
+
+	customPolicy := RetryPolicy{IsRetryable: func(apiErr APIError) bool { return apiErr.Code == 42 }}
+	assert.True(t, customPolicy.isRetryable(APIError{Code: 42, StatusCode: http.StatusBadRequest}))
+	assert.False(t, customPolicy.isRetryable(APIError{StatusCode: http.StatusServiceUnavailable}))
+}
@@ -0,0 +1,111 @@
+package stream
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// timeLayout is the layout Stream uses for timestamps in API requests and
+// responses. Notably it does not include a timezone offset.
+const timeLayout = "2006-01-02T15:04:05"
+
+// Duration is a time.Duration that marshals to and from Stream's string
+// duration representation (eg. "1m2s").
+type Duration struct {
+	time.Duration
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	d.Duration = parsed
+	return nil
+}
+
+// Time is a time.Time that marshals to and from Stream's timestamp format.
+type Time struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Time.Format(timeLayout))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return err
+	}
+
+	t.Time = parsed
+	return nil
+}
+
+// Data represents a Stream actor/object reference, optionally enriched with
+// arbitrary extra fields.
+type Data struct {
+	ID    string
+	Extra map[string]interface{}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Data) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{"id": d.ID}
+	if len(d.Extra) > 0 {
+		m["data"] = d.Extra
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Data) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if id, ok := raw["id"]; ok {
+		if err := json.Unmarshal(id, &d.ID); err != nil {
+			return err
+		}
+		delete(raw, "id")
+	}
+
+	if extra, ok := raw["data"]; ok {
+		if err := json.Unmarshal(extra, &d.Extra); err != nil {
+			return err
+		}
+		delete(raw, "data")
+	}
+
+	return nil
+}
+
+// EnrichedActivity is an activity whose actor, object, and target references
+// have been resolved and enriched by Stream.
+type EnrichedActivity struct {
+	Actor          Data           `json:"actor"`
+	ReactionCounts map[string]int `json:"reaction_counts,omitempty"`
+	Score          float64        `json:"score,omitempty"`
+}
@@ -0,0 +1,72 @@
+package stream
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // this is the algorithm Stream signs webhooks with
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// webhookSignatureHeader is the header Stream sends the webhook signature
+// in, an HMAC-SHA1 of the raw request body keyed with the API secret,
+// base64-encoded.
+const webhookSignatureHeader = "X-Signature"
+
+// WebhookEventType identifies the kind of event a WebhookEvent carries.
+type WebhookEventType string
+
+// Webhook event types Stream sends. This list only covers the events the Go
+// client currently models; unrecognized types still decode successfully,
+// with Type set to the raw string from the payload.
+const (
+	WebhookEventActivityNew    WebhookEventType = "feed.activity.new"
+	WebhookEventActivityUpdate WebhookEventType = "feed.activity.update"
+	WebhookEventActivityDelete WebhookEventType = "feed.activity.delete"
+	WebhookEventReactionNew    WebhookEventType = "feed.reaction.new"
+	WebhookEventReactionDelete WebhookEventType = "feed.reaction.delete"
+	WebhookEventModerationFlag WebhookEventType = "moderation.flag"
+)
+
+// WebhookEvent is a decoded, signature-verified webhook payload sent by
+// Stream. Activity, Reaction, and Moderation are populated depending on
+// Type; fields not relevant to a given event type are left zero.
+type WebhookEvent struct {
+	Type       WebhookEventType       `json:"type"`
+	FeedID     string                 `json:"feed_id,omitempty"`
+	Activity   map[string]interface{} `json:"activity,omitempty"`
+	Reaction   map[string]interface{} `json:"reaction,omitempty"`
+	Moderation map[string]interface{} `json:"moderation,omitempty"`
+}
+
+// VerifyWebhook validates that body was sent by Stream, by checking the
+// signature in header against an HMAC of body keyed with the Client's API
+// secret, and returns the decoded event on success. Framework-specific
+// middleware (see the stream/webhook subpackages) builds on this.
+//
+// Webhooks are always signed with the long-lived API secret, not a
+// TokenProvider: Stream signs the payload server-side against the account
+// secret regardless of how individual requests are authenticated, so
+// rotating credentials via WithTokenProvider has no effect here.
+func (c *Client) VerifyWebhook(body []byte, header http.Header) (*WebhookEvent, error) {
+	sig := header.Get(webhookSignatureHeader)
+	if sig == "" {
+		return nil, fmt.Errorf("stream: missing %s header", webhookSignatureHeader)
+	}
+
+	mac := hmac.New(sha1.New, []byte(c.authenticator.secret))
+	mac.Write(body)
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(sig)) {
+		return nil, fmt.Errorf("stream: invalid webhook signature")
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("stream: invalid webhook payload: %w", err)
+	}
+
+	return &event, nil
+}
@@ -0,0 +1,81 @@
+// Package echo provides Echo middleware that verifies Stream webhook
+// signatures before invoking the wrapped handler chain.
+package echo
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+)
+
+// eventContextKey is the Echo context key a verified *stream.WebhookEvent is
+// stored under.
+const eventContextKey = "stream.webhook.event"
+
+// ErrorWriter builds the error returned to Echo for a request that failed
+// webhook verification.
+type ErrorWriter func(c echo.Context, err error) error
+
+// DefaultErrorWriter replies with 401 Unauthorized and the error text.
+func DefaultErrorWriter(_ echo.Context, err error) error {
+	return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+}
+
+// Option configures the middleware returned by Middleware.
+type Option func(*options)
+
+type options struct {
+	onError ErrorWriter
+}
+
+// WithErrorWriter overrides how verification failures are reported. Defaults
+// to DefaultErrorWriter.
+func WithErrorWriter(ew ErrorWriter) Option {
+	return func(o *options) { o.onError = ew }
+}
+
+// Middleware returns Echo middleware that verifies the incoming request's
+// Stream webhook signature using client, and rejects the request if
+// verification fails. On success, the decoded *stream.WebhookEvent is
+// stored on the Echo context and can be retrieved with Event.
+func Middleware(client *stream.Client, opts ...Option) echo.MiddlewareFunc {
+	o := options{onError: DefaultErrorWriter}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return o.onError(c, err)
+			}
+			req.Body.Close()
+
+			event, err := client.VerifyWebhook(body, req.Header)
+			if err != nil {
+				return o.onError(c, err)
+			}
+
+			// Restore the body so downstream handlers can still read the
+			// raw payload.
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			c.Set(eventContextKey, event)
+			return next(c)
+		}
+	}
+}
+
+// Event returns the *stream.WebhookEvent verified by Middleware for c, or
+// nil if c didn't pass through Middleware.
+func Event(c echo.Context) *stream.WebhookEvent {
+	event, _ := c.Get(eventContextKey).(*stream.WebhookEvent)
+	return event
+}
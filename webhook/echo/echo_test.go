@@ -0,0 +1,77 @@
+package echo_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // matches the algorithm Stream signs webhooks with
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+	streamecho "github.com/flockfw64/stream-go2/v7/webhook/echo"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestMiddleware_verifiesAndRestoresBody(t *testing.T) {
+	client, err := stream.NewClient("key", "secret")
+	require.NoError(t, err)
+
+	body := []byte(`{"type":"feed.reaction.new","feed_id":"user:1"}`)
+
+	var downstreamBody []byte
+	var gotEvent *stream.WebhookEvent
+	handler := streamecho.Middleware(client)(func(c echo.Context) error {
+		downstreamBody, _ = io.ReadAll(c.Request().Body)
+		gotEvent = streamecho.Event(c)
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	req.Header.Set("X-Signature", sign("secret", body))
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, handler(c))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, downstreamBody)
+	require.NotNil(t, gotEvent)
+	assert.Equal(t, stream.WebhookEventType("feed.reaction.new"), gotEvent.Type)
+}
+
+func TestMiddleware_rejectsInvalidSignature(t *testing.T) {
+	client, err := stream.NewClient("key", "secret")
+	require.NoError(t, err)
+
+	called := false
+	handler := streamecho.Middleware(client)(func(c echo.Context) error {
+		called = true
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set("X-Signature", "bogus")
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	err = handler(c)
+
+	assert.False(t, called)
+	require.Error(t, err)
+
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
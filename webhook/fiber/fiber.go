@@ -0,0 +1,71 @@
+// Package fiber provides Fiber middleware that verifies Stream webhook
+// signatures before invoking the wrapped handler chain.
+package fiber
+
+import (
+	"net/http"
+	"net/textproto"
+
+	"github.com/gofiber/fiber/v2"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+)
+
+// eventLocalsKey is the Fiber Locals key a verified *stream.WebhookEvent is
+// stored under.
+const eventLocalsKey = "stream.webhook.event"
+
+// ErrorWriter writes an HTTP response for a request that failed webhook
+// verification.
+type ErrorWriter func(c *fiber.Ctx, err error) error
+
+// DefaultErrorWriter replies with 401 Unauthorized and the error text.
+func DefaultErrorWriter(c *fiber.Ctx, err error) error {
+	return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+}
+
+// Option configures the middleware returned by Middleware.
+type Option func(*options)
+
+type options struct {
+	onError ErrorWriter
+}
+
+// WithErrorWriter overrides how verification failures are written to the
+// response. Defaults to DefaultErrorWriter.
+func WithErrorWriter(ew ErrorWriter) Option {
+	return func(o *options) { o.onError = ew }
+}
+
+// Middleware returns Fiber middleware that verifies the incoming request's
+// Stream webhook signature using client, and rejects the request if
+// verification fails. On success, the decoded *stream.WebhookEvent is
+// stored in Fiber's Locals and can be retrieved with Event.
+func Middleware(client *stream.Client, opts ...Option) fiber.Handler {
+	o := options{onError: DefaultErrorWriter}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(c *fiber.Ctx) error {
+		header := make(http.Header, len(c.GetReqHeaders()))
+		for k, v := range c.GetReqHeaders() {
+			header[textproto.CanonicalMIMEHeaderKey(k)] = v
+		}
+
+		event, err := client.VerifyWebhook(c.Body(), header)
+		if err != nil {
+			return o.onError(c, err)
+		}
+
+		c.Locals(eventLocalsKey, event)
+		return c.Next()
+	}
+}
+
+// Event returns the *stream.WebhookEvent verified by Middleware for c, or
+// nil if c didn't pass through Middleware.
+func Event(c *fiber.Ctx) *stream.WebhookEvent {
+	event, _ := c.Locals(eventLocalsKey).(*stream.WebhookEvent)
+	return event
+}
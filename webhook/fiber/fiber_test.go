@@ -0,0 +1,78 @@
+package fiber_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // matches the algorithm Stream signs webhooks with
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+	streamfiber "github.com/flockfw64/stream-go2/v7/webhook/fiber"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestMiddleware_verifiesAndRestoresBody(t *testing.T) {
+	client, err := stream.NewClient("key", "secret")
+	require.NoError(t, err)
+
+	body := []byte(`{"type":"feed.reaction.new","feed_id":"user:1"}`)
+
+	var downstreamBody []byte
+	var gotEvent *stream.WebhookEvent
+	app := fiber.New()
+	app.Use(streamfiber.Middleware(client))
+	app.Post("/", func(c *fiber.Ctx) error {
+		downstreamBody = c.Body()
+		gotEvent = streamfiber.Event(c)
+		return c.SendStatus(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	req.Header.Set("X-Signature", sign("secret", body))
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, body, downstreamBody)
+	require.NotNil(t, gotEvent)
+	assert.Equal(t, stream.WebhookEventType("feed.reaction.new"), gotEvent.Type)
+}
+
+func TestMiddleware_rejectsInvalidSignature(t *testing.T) {
+	client, err := stream.NewClient("key", "secret")
+	require.NoError(t, err)
+
+	called := false
+	app := fiber.New()
+	app.Use(streamfiber.Middleware(client))
+	app.Post("/", func(c *fiber.Ctx) error {
+		called = true
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set("X-Signature", "bogus")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	assert.False(t, called)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, string(respBody))
+}
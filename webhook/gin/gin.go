@@ -0,0 +1,80 @@
+// Package gin provides Gin middleware that verifies Stream webhook
+// signatures before invoking the wrapped handler chain.
+package gin
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+)
+
+// eventContextKey is the Gin context key a verified *stream.WebhookEvent is
+// stored under.
+const eventContextKey = "stream.webhook.event"
+
+// ErrorWriter writes an HTTP response for a request that failed webhook
+// verification and aborts the context.
+type ErrorWriter func(c *gin.Context, err error)
+
+// DefaultErrorWriter replies with 401 Unauthorized and the error text.
+func DefaultErrorWriter(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+}
+
+// Option configures the middleware returned by Middleware.
+type Option func(*options)
+
+type options struct {
+	onError ErrorWriter
+}
+
+// WithErrorWriter overrides how verification failures are written to the
+// response. Defaults to DefaultErrorWriter.
+func WithErrorWriter(ew ErrorWriter) Option {
+	return func(o *options) { o.onError = ew }
+}
+
+// Middleware returns Gin middleware that verifies the incoming request's
+// Stream webhook signature using client, and aborts the request if
+// verification fails. On success, the decoded *stream.WebhookEvent is
+// stored on the Gin context and can be retrieved with Event.
+func Middleware(client *stream.Client, opts ...Option) gin.HandlerFunc {
+	o := options{onError: DefaultErrorWriter}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			o.onError(c, err)
+			return
+		}
+		c.Request.Body.Close()
+
+		event, err := client.VerifyWebhook(body, c.Request.Header)
+		if err != nil {
+			o.onError(c, err)
+			return
+		}
+
+		// Restore the body so downstream handlers can still read the raw
+		// payload.
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		c.Set(eventContextKey, event)
+		c.Next()
+	}
+}
+
+// Event returns the *stream.WebhookEvent verified by Middleware for c, or
+// nil if c didn't pass through Middleware.
+func Event(c *gin.Context) *stream.WebhookEvent {
+	event, _ := c.Get(eventContextKey)
+	e, _ := event.(*stream.WebhookEvent)
+	return e
+}
@@ -0,0 +1,78 @@
+package gin_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // matches the algorithm Stream signs webhooks with
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+	streamgin "github.com/flockfw64/stream-go2/v7/webhook/gin"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestMiddleware_verifiesAndRestoresBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client, err := stream.NewClient("key", "secret")
+	require.NoError(t, err)
+
+	body := []byte(`{"type":"feed.reaction.new","feed_id":"user:1"}`)
+
+	var downstreamBody []byte
+	var gotEvent *stream.WebhookEvent
+	r := gin.New()
+	r.Use(streamgin.Middleware(client))
+	r.POST("/", func(c *gin.Context) {
+		downstreamBody, _ = io.ReadAll(c.Request.Body)
+		gotEvent = streamgin.Event(c)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	req.Header.Set("X-Signature", sign("secret", body))
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, downstreamBody)
+	require.NotNil(t, gotEvent)
+	assert.Equal(t, stream.WebhookEventType("feed.reaction.new"), gotEvent.Type)
+}
+
+func TestMiddleware_rejectsInvalidSignature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client, err := stream.NewClient("key", "secret")
+	require.NoError(t, err)
+
+	called := false
+	r := gin.New()
+	r.Use(streamgin.Middleware(client))
+	r.POST("/", func(c *gin.Context) {
+		called = true
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set("X-Signature", "bogus")
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,82 @@
+// Package nethttp provides net/http middleware that verifies Stream webhook
+// signatures before invoking the wrapped handler.
+package nethttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+)
+
+type contextKey struct{}
+
+// eventContextKey is the key under which a verified *stream.WebhookEvent is
+// stored on the request context.
+var eventContextKey = contextKey{}
+
+// ErrorWriter writes an HTTP response for a request that failed webhook
+// verification.
+type ErrorWriter func(w http.ResponseWriter, r *http.Request, err error)
+
+// DefaultErrorWriter replies with 401 Unauthorized and the error text.
+func DefaultErrorWriter(w http.ResponseWriter, _ *http.Request, err error) {
+	http.Error(w, err.Error(), http.StatusUnauthorized)
+}
+
+// Option configures the middleware returned by Middleware.
+type Option func(*options)
+
+type options struct {
+	onError ErrorWriter
+}
+
+// WithErrorWriter overrides how verification failures are written to the
+// response. Defaults to DefaultErrorWriter.
+func WithErrorWriter(ew ErrorWriter) Option {
+	return func(o *options) { o.onError = ew }
+}
+
+// Middleware returns net/http middleware that verifies the incoming
+// request's Stream webhook signature using client, and rejects the request
+// if verification fails. On success, the decoded *stream.WebhookEvent is
+// stored on the request context and can be retrieved with Event.
+func Middleware(client *stream.Client, opts ...Option) func(http.Handler) http.Handler {
+	o := options{onError: DefaultErrorWriter}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				o.onError(w, r, err)
+				return
+			}
+			r.Body.Close()
+
+			event, err := client.VerifyWebhook(body, r.Header)
+			if err != nil {
+				o.onError(w, r, err)
+				return
+			}
+
+			// Restore the body so downstream handlers can still read the
+			// raw payload.
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := context.WithValue(r.Context(), eventContextKey, event)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Event returns the *stream.WebhookEvent verified by Middleware for ctx, or
+// nil if ctx didn't pass through Middleware.
+func Event(ctx context.Context) *stream.WebhookEvent {
+	event, _ := ctx.Value(eventContextKey).(*stream.WebhookEvent)
+	return event
+}
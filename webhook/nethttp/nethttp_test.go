@@ -0,0 +1,69 @@
+package nethttp_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // matches the algorithm Stream signs webhooks with
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+	"github.com/flockfw64/stream-go2/v7/webhook/nethttp"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestMiddleware_verifiesAndRestoresBody(t *testing.T) {
+	client, err := stream.NewClient("key", "secret")
+	require.NoError(t, err)
+
+	body := []byte(`{"type":"feed.reaction.new","feed_id":"user:1"}`)
+
+	var downstreamBody []byte
+	var gotEvent *stream.WebhookEvent
+	handler := nethttp.Middleware(client)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downstreamBody, _ = io.ReadAll(r.Body)
+		gotEvent = nethttp.Event(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	req.Header.Set("X-Signature", sign("secret", body))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, downstreamBody)
+	require.NotNil(t, gotEvent)
+	assert.Equal(t, stream.WebhookEventType("feed.reaction.new"), gotEvent.Type)
+}
+
+func TestMiddleware_rejectsInvalidSignature(t *testing.T) {
+	client, err := stream.NewClient("key", "secret")
+	require.NoError(t, err)
+
+	called := false
+	handler := nethttp.Middleware(client)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set("X-Signature", "bogus")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
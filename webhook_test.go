@@ -0,0 +1,64 @@
+package stream_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // matches the algorithm Stream signs webhooks with
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/flockfw64/stream-go2/v7"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestClient_VerifyWebhook(t *testing.T) {
+	c, err := stream.NewClient("key", "secret")
+	require.NoError(t, err)
+
+	body := []byte(`{"type":"feed.activity.new","feed_id":"user:1","activity":{"id":"a1"}}`)
+	validSig := sign("secret", body)
+
+	testCases := []struct {
+		name      string
+		header    http.Header
+		shouldErr bool
+	}{
+		{name: "missing signature header", header: http.Header{}, shouldErr: true},
+		{name: "invalid signature", header: http.Header{"X-Signature": []string{"bogus"}}, shouldErr: true},
+		{name: "signature from wrong secret", header: http.Header{"X-Signature": []string{sign("other", body)}}, shouldErr: true},
+		{name: "valid signature", header: http.Header{"X-Signature": []string{validSig}}, shouldErr: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event, err := c.VerifyWebhook(body, tc.header)
+			if tc.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, stream.WebhookEventActivityNew, event.Type)
+			assert.Equal(t, "user:1", event.FeedID)
+			assert.Equal(t, "a1", event.Activity["id"])
+		})
+	}
+}
+
+func TestClient_VerifyWebhook_moderationEvent(t *testing.T) {
+	c, err := stream.NewClient("key", "secret")
+	require.NoError(t, err)
+
+	body := []byte(`{"type":"moderation.flag","moderation":{"entity_id":"a1","reason":"spam"}}`)
+
+	event, err := c.VerifyWebhook(body, http.Header{"X-Signature": []string{sign("secret", body)}})
+	require.NoError(t, err)
+	assert.Equal(t, stream.WebhookEventModerationFlag, event.Type)
+	assert.Equal(t, "spam", event.Moderation["reason"])
+}